@@ -0,0 +1,214 @@
+// Package analyze walks a Git repository's working tree, classifies its
+// files by language, and tallies commits by author.
+package analyze
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamalte/GOrgAnalyzer/detect"
+	"github.com/kamalte/GOrgAnalyzer/gitignore"
+)
+
+// FileResult describes the detected language, confidence, and size of a
+// single analyzed file.
+type FileResult struct {
+	Path       string
+	Language   string
+	Confidence float64
+	Bytes      int64
+}
+
+// loadAttributes parses repoPath's .gitattributes file, returning an empty
+// rule set if the repository doesn't have one.
+func loadAttributes(repoPath string) (*detect.Attributes, error) {
+	file, err := os.Open(filepath.Join(repoPath, ".gitattributes"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return detect.NewAttributes(), nil
+		}
+		return nil, fmt.Errorf("opening .gitattributes: %w", err)
+	}
+	defer file.Close()
+
+	attrs, err := detect.ParseGitAttributes(file)
+	if err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// maxSniffBytes bounds how much of a file detect reads for shebang and
+// content-based classification; byte counts always use the full file
+// size regardless of this cap.
+const maxSniffBytes = 16 * 1024
+
+// analyzeLanguages walks repoPath and classifies every non-ignored file,
+// returning per-file results alongside the aggregated byte counts per
+// language.
+func analyzeLanguages(repoPath string) ([]FileResult, map[string]int, int, error) {
+	attrs, err := loadAttributes(repoPath)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	detector := detect.NewDetector(attrs)
+
+	patterns, err := gitignore.ReadPatterns(repoPath)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("reading .gitignore files: %w", err)
+	}
+	matcher := gitignore.NewMatcher(patterns)
+
+	var results []FileResult
+	langByteCounts := make(map[string]int)
+	totalBytes := 0
+
+	err = filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Printf("Warning: Skipping invalid path %s: %v", path, err)
+			return nil
+		}
+		if path == repoPath {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(repoPath, path)
+		if err != nil {
+			log.Printf("Warning: Unable to relativize %s: %v", path, err)
+			return nil
+		}
+		segments := strings.Split(filepath.ToSlash(relPath), "/")
+
+		if segments[0] == ".git" {
+			return filepath.SkipDir
+		}
+		if matcher.Match(segments, info.IsDir()) == gitignore.Exclude {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		content, err := readSniffBytes(path, maxSniffBytes)
+		if err != nil {
+			log.Printf("Warning: Unable to read file %s: %v", path, err)
+			return nil
+		}
+
+		result := detector.Detect(relPath, content)
+		if result.Excluded || result.Language == "" {
+			return nil
+		}
+
+		byteCount := info.Size()
+		results = append(results, FileResult{
+			Path:       relPath,
+			Language:   result.Language,
+			Confidence: result.Confidence,
+			Bytes:      byteCount,
+		})
+		langByteCounts[result.Language] += int(byteCount)
+		totalBytes += int(byteCount)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("walking file path: %w", err)
+	}
+
+	return results, langByteCounts, totalBytes, nil
+}
+
+// readSniffBytes reads up to limit bytes of path, for classification use
+// only.
+func readSniffBytes(path string, limit int) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, limit)
+	n, err := file.Read(buf)
+	if err != nil && n == 0 {
+		if err.Error() == "EOF" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// analyzeCommitsByAuthor counts commits by author in a Git repository.
+func analyzeCommitsByAuthor(repoPath string) (map[string]int, error) {
+	cmd := exec.Command("git", "-C", repoPath, "log", "--pretty=%an")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git log: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, author := range strings.Split(string(output), "\n") {
+		if author != "" {
+			counts[author]++
+		}
+	}
+	return counts, nil
+}
+
+// repoStats is the outcome of scanning a repository from scratch:
+// per-file language detections, their aggregate byte counts, and commits
+// by author.
+type repoStats struct {
+	files         []FileResult
+	langBytes     map[string]int
+	totalBytes    int
+	authorCommits map[string]int
+}
+
+// scanRepo walks repoPath's working tree and its commit history, without
+// consulting or updating any cache.
+func scanRepo(repoPath string) (repoStats, error) {
+	commitCounts, err := analyzeCommitsByAuthor(repoPath)
+	if err != nil {
+		return repoStats{}, fmt.Errorf("analyzing commits: %w", err)
+	}
+
+	files, langCounts, totalBytes, err := analyzeLanguages(repoPath)
+	if err != nil {
+		return repoStats{}, fmt.Errorf("analyzing languages: %w", err)
+	}
+
+	return repoStats{
+		files:         files,
+		langBytes:     langCounts,
+		totalBytes:    totalBytes,
+		authorCommits: commitCounts,
+	}, nil
+}
+
+// printRepoStats prints the per-repo summary in the CLI's usual format.
+func printRepoStats(repoPath string, stats repoStats) {
+	fmt.Printf("\n")
+	fmt.Printf("\n")
+	fmt.Printf("*********************************************\n")
+	fmt.Printf("🔍 Analyzing Git repository: %s\n", repoPath)
+
+	fmt.Println("📊 Commits by author:")
+	for author, count := range stats.authorCommits {
+		fmt.Printf("👤 %s: %d\n", author, count)
+	}
+
+	fmt.Println("📊 Language statistics:")
+	for lang, byteCount := range stats.langBytes {
+		percentage := (float64(byteCount) / float64(stats.totalBytes)) * 100
+		fmt.Printf("📝 %s: %d bytes (%.2f%%)\n", lang, byteCount, percentage)
+	}
+}
@@ -0,0 +1,228 @@
+package analyze
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamalte/GOrgAnalyzer/detect"
+	"github.com/kamalte/GOrgAnalyzer/gitignore"
+	"github.com/kamalte/GOrgAnalyzer/store"
+)
+
+// Options configures how ProcessGitRepo uses the on-disk stats cache.
+type Options struct {
+	// Store, when non-nil, caches results keyed by the repository's HEAD
+	// SHA and is consulted before any re-scan.
+	Store *store.Store
+	// Force bypasses the cache even when Store is set, always rescanning
+	// and then overwriting the stored entry.
+	Force bool
+	// Incremental, when set alongside Store, limits a cache miss to a
+	// differential re-read of files that changed between the previously
+	// stored SHA and HEAD (via `git diff --name-only`) instead of a full
+	// rescan. It has no effect on ProcessGitRepoAtRev, which always does
+	// a full tree scan.
+	Incremental bool
+}
+
+// RepoResult is a repository's complete analysis. Processing functions
+// return it by value rather than mutating shared totals in place, so
+// callers running many repositories concurrently can fold results into
+// shared state on a single goroutine instead of synchronizing writers.
+type RepoResult struct {
+	RepoPath      string
+	LangBytes     map[string]int
+	TotalBytes    int
+	AuthorCommits map[string]int
+	// Files holds each analyzed file's detected language, confidence, and
+	// byte size. It is empty when the result is served from the cache,
+	// and after an incremental re-read every file's Confidence is 0, since
+	// the store keeps no confidences.
+	Files []FileResult
+}
+
+func toResult(repoPath string, stats repoStats) RepoResult {
+	return RepoResult{
+		RepoPath:      repoPath,
+		LangBytes:     stats.langBytes,
+		TotalBytes:    stats.totalBytes,
+		AuthorCommits: stats.authorCommits,
+		Files:         stats.files,
+	}
+}
+
+// ProcessGitRepo analyzes a single Git repository for commits and
+// languages. When opts.Store is set and the repository's HEAD SHA
+// matches the stored one, the scan is skipped entirely in favor of the
+// cached stats.
+func ProcessGitRepo(repoPath string, opts Options) (RepoResult, error) {
+	if opts.Store == nil {
+		stats, err := scanRepo(repoPath)
+		if err != nil {
+			return RepoResult{}, fmt.Errorf("analyzing repository %s: %w", repoPath, err)
+		}
+		printRepoStats(repoPath, stats)
+		return toResult(repoPath, stats), nil
+	}
+
+	sha, err := headSHA(repoPath)
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("resolving HEAD for %s: %w", repoPath, err)
+	}
+
+	storedSHA, err := opts.Store.HeadSHA(repoPath)
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("reading cache for %s: %w", repoPath, err)
+	}
+
+	if !opts.Force && sha != "" && storedSHA == sha {
+		stats, err := loadCached(opts.Store, repoPath, sha)
+		if err != nil {
+			return RepoResult{}, fmt.Errorf("loading cached stats for %s: %w", repoPath, err)
+		}
+		fmt.Printf("\n♻️  %s unchanged at %s, using cached stats\n", repoPath, shortSHA(sha))
+		return toResult(repoPath, stats), nil
+	}
+
+	var (
+		stats repoStats
+		files map[string]store.FileLanguage
+	)
+	if !opts.Force && opts.Incremental && storedSHA != "" {
+		stats, files, err = diffScan(opts.Store, repoPath, storedSHA)
+		if err != nil {
+			return RepoResult{}, fmt.Errorf("diffing %s since %s: %w", repoPath, storedSHA, err)
+		}
+	} else {
+		stats, err = scanRepo(repoPath)
+		if err != nil {
+			return RepoResult{}, fmt.Errorf("analyzing repository %s: %w", repoPath, err)
+		}
+		files = filesByPath(stats.files)
+	}
+
+	printRepoStats(repoPath, stats)
+
+	if err := opts.Store.Upsert(repoPath, sha, stats.langBytes, stats.authorCommits, files); err != nil {
+		log.Printf("Error caching stats for %s: %v", repoPath, err)
+	}
+	return toResult(repoPath, stats), nil
+}
+
+func filesByPath(results []FileResult) map[string]store.FileLanguage {
+	files := make(map[string]store.FileLanguage, len(results))
+	for _, r := range results {
+		files[r.Path] = store.FileLanguage{Path: r.Path, Language: r.Language, Bytes: int(r.Bytes)}
+	}
+	return files
+}
+
+func loadCached(s *store.Store, repoPath, sha string) (repoStats, error) {
+	langBytes, err := s.LanguageBytes(repoPath, sha)
+	if err != nil {
+		return repoStats{}, err
+	}
+	authorCommits, err := s.AuthorCommits(repoPath, sha)
+	if err != nil {
+		return repoStats{}, err
+	}
+	total := 0
+	for _, b := range langBytes {
+		total += b
+	}
+	return repoStats{langBytes: langBytes, authorCommits: authorCommits, totalBytes: total}, nil
+}
+
+// diffScan recomputes stats for repoPath by re-classifying only the files
+// that changed between sinceSHA and HEAD, starting from the cached
+// per-file records stored under sinceSHA. Commit counts by author are
+// always recomputed in full, since `git log` is cheap relative to
+// re-walking the tree.
+func diffScan(s *store.Store, repoPath, sinceSHA string) (repoStats, map[string]store.FileLanguage, error) {
+	oldFiles, err := s.Files(repoPath, sinceSHA)
+	if err != nil {
+		return repoStats{}, nil, fmt.Errorf("loading cached files: %w", err)
+	}
+
+	changed, err := changedFilesSince(repoPath, sinceSHA)
+	if err != nil {
+		return repoStats{}, nil, err
+	}
+
+	attrs, err := loadAttributes(repoPath)
+	if err != nil {
+		return repoStats{}, nil, err
+	}
+	detector := detect.NewDetector(attrs)
+
+	patterns, err := gitignore.ReadPatterns(repoPath)
+	if err != nil {
+		return repoStats{}, nil, fmt.Errorf("reading .gitignore files: %w", err)
+	}
+	matcher := gitignore.NewMatcher(patterns)
+
+	files := make(map[string]store.FileLanguage, len(oldFiles))
+	for path, f := range oldFiles {
+		files[path] = f
+	}
+
+	for _, rel := range changed {
+		delete(files, rel)
+
+		fullPath := filepath.Join(repoPath, rel)
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			continue // file was deleted
+		}
+
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+		if matcher.Match(segments, info.IsDir()) == gitignore.Exclude || info.IsDir() {
+			continue
+		}
+
+		content, err := readSniffBytes(fullPath, maxSniffBytes)
+		if err != nil {
+			log.Printf("Warning: Unable to read file %s: %v", fullPath, err)
+			continue
+		}
+
+		result := detector.Detect(rel, content)
+		if result.Excluded || result.Language == "" {
+			continue
+		}
+
+		files[rel] = store.FileLanguage{Path: rel, Language: result.Language, Bytes: int(info.Size())}
+	}
+
+	commitCounts, err := analyzeCommitsByAuthor(repoPath)
+	if err != nil {
+		return repoStats{}, nil, fmt.Errorf("analyzing commits: %w", err)
+	}
+
+	langBytes := make(map[string]int)
+	totalBytes := 0
+	fileResults := make([]FileResult, 0, len(files))
+	for _, f := range files {
+		langBytes[f.Language] += f.Bytes
+		totalBytes += f.Bytes
+		fileResults = append(fileResults, FileResult{Path: f.Path, Language: f.Language, Bytes: int64(f.Bytes)})
+	}
+
+	stats := repoStats{
+		files:         fileResults,
+		langBytes:     langBytes,
+		totalBytes:    totalBytes,
+		authorCommits: commitCounts,
+	}
+	return stats, files, nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
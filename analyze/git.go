@@ -0,0 +1,35 @@
+package analyze
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// headSHA returns the current HEAD commit SHA of repoPath.
+func headSHA(repoPath string) (string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running git rev-parse HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// changedFilesSince returns the repository-root-relative paths that
+// differ between sinceSHA and HEAD.
+func changedFilesSince(repoPath, sinceSHA string) ([]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "diff", "--name-only", sinceSHA, "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running git diff --name-only: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,218 @@
+package analyze
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/kamalte/GOrgAnalyzer/detect"
+	"github.com/kamalte/GOrgAnalyzer/gitignore"
+)
+
+// ProcessGitRepoAtRev analyzes repoPath's tree at rev (a branch, tag, or
+// commit SHA; defaults to HEAD) instead of the working tree. Because it
+// reads blobs straight out of the object database via go-git, it works
+// against bare repositories and arbitrary historical revisions without
+// requiring a checkout, and byte counts always reflect exactly what's
+// committed.
+//
+// When opts.Store is set and the resolved revision's SHA matches the
+// stored one, the scan is skipped in favor of the cached stats, the same
+// way ProcessGitRepo short-circuits on an unchanged HEAD. opts.Incremental
+// is not honored here: a cache miss always does a full tree scan, since
+// diffing two historical trees needs none of the working-tree machinery
+// diffScan relies on.
+func ProcessGitRepoAtRev(repoPath, rev string, opts Options) (RepoResult, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("opening repository %s: %w", repoPath, err)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("resolving revision %q in %s: %w", rev, repoPath, err)
+	}
+	sha := hash.String()
+
+	if opts.Store != nil && !opts.Force {
+		storedSHA, err := opts.Store.HeadSHA(repoPath)
+		if err != nil {
+			return RepoResult{}, fmt.Errorf("reading cache for %s: %w", repoPath, err)
+		}
+		if storedSHA == sha {
+			stats, err := loadCached(opts.Store, repoPath, sha)
+			if err != nil {
+				return RepoResult{}, fmt.Errorf("loading cached stats for %s: %w", repoPath, err)
+			}
+			fmt.Printf("\n♻️  %s@%s unchanged, using cached stats\n", repoPath, shortSHA(sha))
+			return toResult(repoPath, stats), nil
+		}
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("loading commit %s: %w", hash, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("loading tree for commit %s: %w", hash, err)
+	}
+
+	attrs, patterns, err := loadTreeRules(tree)
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("loading .gitattributes/.gitignore from tree: %w", err)
+	}
+	detector := detect.NewDetector(attrs)
+	matcher := gitignore.NewMatcher(patterns)
+
+	var files []FileResult
+	langByteCounts := make(map[string]int)
+	totalBytes := 0
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		segments := strings.Split(f.Name, "/")
+		if matcher.Match(segments, false) == gitignore.Exclude {
+			return nil
+		}
+
+		content, err := sniffTreeFile(f, maxSniffBytes)
+		if err != nil {
+			log.Printf("Warning: Unable to read %s from tree: %v", f.Name, err)
+			return nil
+		}
+
+		result := detector.Detect(f.Name, content)
+		if result.Excluded || result.Language == "" {
+			return nil
+		}
+
+		files = append(files, FileResult{Path: f.Name, Language: result.Language, Confidence: result.Confidence, Bytes: f.Size})
+		langByteCounts[result.Language] += int(f.Size)
+		totalBytes += int(f.Size)
+		return nil
+	})
+	if err != nil {
+		return RepoResult{}, fmt.Errorf("walking tree: %w", err)
+	}
+
+	commitCounts, err := commitsByAuthorAtRev(repo, *hash)
+	if err != nil {
+		log.Printf("Error analyzing commits in %s: %v", repoPath, err)
+		commitCounts = make(map[string]int)
+	}
+
+	stats := repoStats{
+		files:         files,
+		langBytes:     langByteCounts,
+		totalBytes:    totalBytes,
+		authorCommits: commitCounts,
+	}
+	printRepoStats(fmt.Sprintf("%s@%s", repoPath, rev), stats)
+
+	if opts.Store != nil {
+		if err := opts.Store.Upsert(repoPath, sha, stats.langBytes, stats.authorCommits, filesByPath(stats.files)); err != nil {
+			log.Printf("Error caching stats for %s: %v", repoPath, err)
+		}
+	}
+	return toResult(repoPath, stats), nil
+}
+
+// sniffTreeFile reads up to limit bytes of a tree file's contents, for
+// classification use only; byte counts always come from File.Size.
+func sniffTreeFile(f *object.File, limit int) ([]byte, error) {
+	reader, err := f.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	buf := make([]byte, limit)
+	n, err := reader.Read(buf)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// commitsByAuthorAtRev counts commits reachable from hash by author,
+// mirroring analyzeCommitsByAuthor but over go-git's object graph instead
+// of shelling out to `git log`.
+func commitsByAuthorAtRev(repo *git.Repository, hash plumbing.Hash) (map[string]int, error) {
+	commitIter, err := repo.Log(&git.LogOptions{From: hash})
+	if err != nil {
+		return nil, fmt.Errorf("walking commit log: %w", err)
+	}
+	defer commitIter.Close()
+
+	counts := make(map[string]int)
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		counts[c.Author.Name]++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("iterating commit log: %w", err)
+	}
+	return counts, nil
+}
+
+// loadTreeRules scans tree for the root .gitattributes file and every
+// .gitignore blob at any depth, compiling them the same way the
+// working-tree path does, so a historical revision is classified under
+// the rules that were committed alongside it rather than whatever's
+// currently checked out.
+func loadTreeRules(tree *object.Tree) (*detect.Attributes, []*gitignore.Pattern, error) {
+	attrs := detect.NewAttributes()
+	var patterns []*gitignore.Pattern
+
+	err := tree.Files().ForEach(func(f *object.File) error {
+		base := filepath.Base(f.Name)
+		if base != ".gitattributes" && base != ".gitignore" {
+			return nil
+		}
+
+		reader, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		if base == ".gitattributes" {
+			if filepath.Dir(f.Name) != "." {
+				return nil // only the repository-root .gitattributes is honored today
+			}
+			parsed, err := detect.ParseGitAttributes(reader)
+			if err != nil {
+				return err
+			}
+			attrs = parsed
+			return nil
+		}
+
+		dir := filepath.Dir(f.Name)
+		var domain []string
+		if dir != "." {
+			domain = strings.Split(dir, "/")
+		}
+		filePatterns, err := gitignore.ReadPatternsReader(reader, domain)
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, filePatterns...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return attrs, patterns, nil
+}
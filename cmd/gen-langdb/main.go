@@ -0,0 +1,80 @@
+// Command gen-langdb refreshes langdb's embedded language dataset. It
+// fetches GitHub Linguist's languages.yml, converts it to the compact
+// JSON shape langdb.Entry expects, and writes it out — so picking up
+// upstream's latest colors and languages is a single command:
+//
+//	go generate ./langdb/...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const languagesYMLURL = "https://raw.githubusercontent.com/github-linguist/linguist/main/lib/linguist/languages.yml"
+
+// linguistLanguage mirrors the fields of languages.yml that langdb.Entry
+// cares about; upstream's file carries a good deal more we don't need.
+type linguistLanguage struct {
+	Color      string   `yaml:"color"`
+	Extensions []string `yaml:"extensions"`
+	Aliases    []string `yaml:"aliases"`
+	Type       string   `yaml:"type"`
+}
+
+func main() {
+	out := flag.String("out", "languages.json", "path to write the converted dataset to")
+	flag.Parse()
+
+	if err := run(*out); err != nil {
+		fmt.Fprintln(os.Stderr, "gen-langdb:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string) error {
+	body, err := fetchLanguagesYML()
+	if err != nil {
+		return err
+	}
+
+	var languages map[string]linguistLanguage
+	if err := yaml.Unmarshal(body, &languages); err != nil {
+		return fmt.Errorf("parsing languages.yml: %w", err)
+	}
+
+	data, err := json.MarshalIndent(languages, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding languages.json: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.WriteFile(out, data, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", out, err)
+	}
+	return nil
+}
+
+func fetchLanguagesYML() ([]byte, error) {
+	resp, err := http.Get(languagesYMLURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching languages.yml: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching languages.yml: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading languages.yml: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,59 @@
+package detect
+
+import "strings"
+
+// tokenWeights gives each candidate language a small set of tokens that
+// are distinctive of it, with a weight reflecting how strongly the token's
+// presence implies that language. This is a much smaller stand-in for
+// go-enry's trained naive Bayes classifier, scored the same way: count
+// weighted token occurrences per candidate and take the highest scorer.
+var tokenWeights = map[string]map[string]float64{
+	"C": {
+		"printf(":  1.0,
+		"#include": 0.5,
+		"malloc(":  1.0,
+		"typedef":  0.5,
+	},
+	"C++": {
+		"std::":     2.0,
+		"template<": 2.0,
+		"namespace": 1.5,
+		"cout":      1.0,
+		"#include":  0.3,
+	},
+	"Objective-C": {
+		"@interface":    2.0,
+		"@implementation": 2.0,
+		"NSString":      1.5,
+	},
+	"MATLAB": {
+		"endfunction": 2.0,
+		"function [":  1.5,
+		"%%":          0.5,
+	},
+}
+
+// classifyContent ranks candidates by weighted token frequency in content
+// and returns them best-first. Candidates with a zero score keep their
+// original relative order at the end of the result, so callers always get
+// a deterministic, complete ranking rather than an arbitrary one.
+func classifyContent(content []byte, candidates []string) []string {
+	text := string(content)
+	scores := make(map[string]float64, len(candidates))
+	for _, lang := range candidates {
+		for token, weight := range tokenWeights[lang] {
+			scores[lang] += float64(strings.Count(text, token)) * weight
+		}
+	}
+
+	ranked := make([]string, len(candidates))
+	copy(ranked, candidates)
+	// Stable insertion sort on score descending keeps zero-score
+	// candidates in their original, caller-supplied order.
+	for i := 1; i < len(ranked); i++ {
+		for j := i; j > 0 && scores[ranked[j]] > scores[ranked[j-1]]; j-- {
+			ranked[j], ranked[j-1] = ranked[j-1], ranked[j]
+		}
+	}
+	return ranked
+}
@@ -0,0 +1,134 @@
+// Package detect classifies source files by programming language the way
+// github-linguist and go-enry do: .gitattributes overrides first, then
+// filename and extension rules, then shebang sniffing for extensionless
+// scripts, then content-based classification to disambiguate extensions
+// shared by more than one language. Files matched by linguist-vendored,
+// linguist-generated, or linguist-documentation are reported as excluded
+// rather than classified.
+package detect
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Classifier ranks candidate languages for a file given its path and
+// content. Implementations may use any scoring strategy; Detector's
+// built-in content classifier is a simple weighted-token scorer.
+type Classifier interface {
+	Classify(path string, content []byte, candidates map[string]float64) []string
+}
+
+// Result is the outcome of classifying a single file.
+type Result struct {
+	Language   string
+	Confidence float64
+	Excluded   bool
+}
+
+// Detector classifies files within a single repository, applying that
+// repository's .gitattributes overrides.
+type Detector struct {
+	attrs      *Attributes
+	classifier Classifier
+}
+
+// NewDetector returns a Detector that applies attrs (use NewAttributes()
+// for a repository with no .gitattributes file) and falls back to the
+// package's built-in content classifier.
+func NewDetector(attrs *Attributes) *Detector {
+	return &Detector{attrs: attrs, classifier: contentClassifier{}}
+}
+
+// WithClassifier overrides the content classifier used to disambiguate
+// extensions with multiple language candidates.
+func (d *Detector) WithClassifier(c Classifier) *Detector {
+	d.classifier = c
+	return d
+}
+
+// Detect classifies the file at relPath (slash-separated, relative to the
+// repository root) given its content. content may be a prefix of the
+// file's true bytes; it is only consulted for shebang and ambiguous-
+// extension classification, never for size accounting.
+func (d *Detector) Detect(relPath string, content []byte) Result {
+	if lang, vendored, generated, documentation := d.attrs.Lookup(relPath); vendored || generated || documentation {
+		return Result{Language: lang, Excluded: true}
+	} else if lang != "" {
+		return Result{Language: lang, Confidence: 1.0}
+	}
+
+	base := filepath.Base(relPath)
+	if lang, ok := filenameRules[base]; ok {
+		return Result{Language: lang, Confidence: 1.0}
+	}
+
+	ext := strings.ToLower(filepath.Ext(base))
+	if candidates, ok := extensionRules[ext]; ok {
+		if len(candidates) == 1 {
+			return Result{Language: candidates[0], Confidence: 1.0}
+		}
+		return d.classifyAmbiguous(relPath, content, candidates)
+	}
+
+	if ext == "" {
+		if lang, ok := classifyShebang(content); ok {
+			return Result{Language: lang, Confidence: 0.9}
+		}
+	}
+
+	return Result{}
+}
+
+func (d *Detector) classifyAmbiguous(relPath string, content []byte, candidates []string) Result {
+	weights := make(map[string]float64, len(candidates))
+	for _, c := range candidates {
+		weights[c] = 1.0 / float64(len(candidates))
+	}
+	ranked := d.classifier.Classify(relPath, content, weights)
+	if len(ranked) == 0 {
+		return Result{Language: candidates[0], Confidence: 1.0 / float64(len(candidates))}
+	}
+	return Result{Language: ranked[0], Confidence: 1.0 / float64(len(candidates))}
+}
+
+// classifyShebang inspects the first line of content for a `#!`
+// interpreter directive and maps it to a language via interpreterRules.
+func classifyShebang(content []byte) (string, bool) {
+	nl := bytes.IndexByte(content, '\n')
+	if nl == -1 {
+		nl = len(content)
+	}
+	line := string(content[:nl])
+	if !strings.HasPrefix(line, "#!") {
+		return "", false
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return "", false
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" && len(fields) > 1 {
+		interpreter = fields[1]
+	}
+	lang, ok := interpreterRules[interpreter]
+	return lang, ok
+}
+
+// contentClassifier is the package's default Classifier, used when no
+// override is supplied via Detector.WithClassifier.
+type contentClassifier struct{}
+
+func (contentClassifier) Classify(_ string, content []byte, candidates map[string]float64) []string {
+	// Sorted by name so classifyContent's tie-break order is deterministic
+	// rather than whatever order map iteration happens to produce.
+	names := make([]string, 0, len(candidates))
+	for name := range candidates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return classifyContent(content, names)
+}
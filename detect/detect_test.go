@@ -0,0 +1,83 @@
+package detect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectAmbiguousExtension(t *testing.T) {
+	d := NewDetector(NewAttributes())
+
+	cases := []struct {
+		name    string
+		path    string
+		content string
+		want    string
+	}{
+		{"C header", "foo.h", "#include <stdio.h>\nint main() { printf(\"hi\"); return 0; }\n", "C"},
+		{"C++ header", "foo.h", "#include <string>\nnamespace app { std::string name; }\n", "C++"},
+		{"Objective-C source", "foo.m", "@interface Foo : NSObject\n@end\n@implementation Foo\n@end\n", "Objective-C"},
+		{"MATLAB source", "foo.m", "function [y] = foo(x)\n  y = x * 2;\nendfunction\n", "MATLAB"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := d.Detect(c.path, []byte(c.content))
+			if result.Language != c.want {
+				t.Errorf("Detect(%s) = %q, want %q", c.path, result.Language, c.want)
+			}
+		})
+	}
+}
+
+// TestDetectAmbiguousExtensionZeroScoreTie covers content with no token
+// distinguishing any candidate language, where classifyContent's scores
+// are all zero and the result depends entirely on tie-break order. This
+// must come out the same way every run, not vary with map iteration
+// order.
+func TestDetectAmbiguousExtensionZeroScoreTie(t *testing.T) {
+	d := NewDetector(NewAttributes())
+
+	for i := 0; i < 50; i++ {
+		result := d.Detect("foo.h", []byte("// comment only\n"))
+		if result.Language != "C" {
+			t.Fatalf("run %d: Detect(foo.h) = %q, want %q", i, result.Language, "C")
+		}
+	}
+}
+
+func TestDetectGitAttributesPrecedence(t *testing.T) {
+	attrs, err := ParseGitAttributes(strings.NewReader(strings.Join([]string{
+		"vendor/* linguist-vendored",
+		"vendor/special.go -linguist-vendored",
+		"docs/*.md linguist-documentation",
+		"*.gen.go linguist-generated",
+	}, "\n")))
+	if err != nil {
+		t.Fatalf("ParseGitAttributes: %v", err)
+	}
+	d := NewDetector(attrs)
+
+	cases := []struct {
+		name         string
+		path         string
+		wantExcluded bool
+		wantLanguage string
+	}{
+		{"vendored file is excluded", "vendor/lib.go", true, ""},
+		{"unvendored override within vendor", "vendor/special.go", false, "Go"},
+		{"documentation file is excluded", "docs/readme.md", true, ""},
+		{"generated file is excluded", "models.gen.go", true, ""},
+		{"ordinary file is not excluded", "main.go", false, "Go"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := d.Detect(c.path, nil)
+			if result.Excluded != c.wantExcluded {
+				t.Errorf("Detect(%s).Excluded = %v, want %v", c.path, result.Excluded, c.wantExcluded)
+			}
+			if result.Language != c.wantLanguage {
+				t.Errorf("Detect(%s).Language = %q, want %q", c.path, result.Language, c.wantLanguage)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package detect
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Attributes holds the linguist-related directives (linguist-language,
+// linguist-vendored, linguist-generated, linguist-documentation, and their
+// gitlab-language alias) parsed out of a .gitattributes file.
+type Attributes struct {
+	rules []attrRule
+}
+
+type attrRule struct {
+	pattern       string
+	language      string
+	vendored      *bool
+	generated     *bool
+	documentation *bool
+}
+
+// NewAttributes returns an empty rule set, used when a repository has no
+// .gitattributes file.
+func NewAttributes() *Attributes {
+	return &Attributes{}
+}
+
+// ParseGitAttributes reads linguist-related attribute lines from r. Lines
+// that don't set a linguist-language, gitlab-language, linguist-vendored,
+// linguist-generated, or linguist-documentation attribute are ignored,
+// since this parser only serves language classification, not the full
+// gitattributes grammar (merge drivers, filters, etc).
+func ParseGitAttributes(r io.Reader) (*Attributes, error) {
+	attrs := &Attributes{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		rule := attrRule{pattern: fields[0]}
+		relevant := false
+		for _, attr := range fields[1:] {
+			switch {
+			case strings.HasPrefix(attr, "linguist-language=") || strings.HasPrefix(attr, "gitlab-language="):
+				rule.language = strings.TrimPrefix(strings.TrimPrefix(attr, "linguist-language="), "gitlab-language=")
+				rule.language = strings.SplitN(rule.language, "?", 2)[0] // gitlab-language=lang?flag
+				relevant = true
+			case attr == "linguist-vendored" || attr == "linguist-vendored=true":
+				rule.vendored = boolPtr(true)
+				relevant = true
+			case attr == "linguist-vendored=false" || attr == "-linguist-vendored":
+				rule.vendored = boolPtr(false)
+				relevant = true
+			case attr == "linguist-generated" || attr == "linguist-generated=true":
+				rule.generated = boolPtr(true)
+				relevant = true
+			case attr == "linguist-generated=false" || attr == "-linguist-generated":
+				rule.generated = boolPtr(false)
+				relevant = true
+			case attr == "linguist-documentation" || attr == "linguist-documentation=true":
+				rule.documentation = boolPtr(true)
+				relevant = true
+			case attr == "linguist-documentation=false" || attr == "-linguist-documentation":
+				rule.documentation = boolPtr(false)
+				relevant = true
+			}
+		}
+		if relevant {
+			attrs.rules = append(attrs.rules, rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading .gitattributes: %w", err)
+	}
+	return attrs, nil
+}
+
+// Lookup returns the linguist overrides that apply to relPath, the
+// slash-separated path of a file relative to the repository root. Later
+// rules in the file win, matching git's own attribute precedence.
+func (a *Attributes) Lookup(relPath string) (language string, vendored, generated, documentation bool) {
+	if a == nil {
+		return "", false, false, false
+	}
+	relPath = filepath.ToSlash(relPath)
+	for _, rule := range a.rules {
+		matched, err := filepath.Match(rule.pattern, filepath.Base(relPath))
+		if err != nil || !matched {
+			matched, _ = filepath.Match(rule.pattern, relPath)
+		}
+		if !matched {
+			continue
+		}
+		if rule.language != "" {
+			language = rule.language
+		}
+		if rule.vendored != nil {
+			vendored = *rule.vendored
+		}
+		if rule.generated != nil {
+			generated = *rule.generated
+		}
+		if rule.documentation != nil {
+			documentation = *rule.documentation
+		}
+	}
+	return language, vendored, generated, documentation
+}
+
+func boolPtr(b bool) *bool { return &b }
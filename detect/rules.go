@@ -0,0 +1,42 @@
+package detect
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed data/extensions.json
+var extensionsData []byte
+
+//go:embed data/filenames.json
+var filenamesData []byte
+
+//go:embed data/interpreters.json
+var interpretersData []byte
+
+// extensionRules maps a lower-cased file extension to the language
+// candidates it can represent, in the order go-enry's languages.yml lists
+// them. Extensions with more than one candidate are ambiguous and require
+// content-based classification.
+var extensionRules map[string][]string
+
+// filenameRules maps an exact, case-sensitive file name to a language,
+// taking precedence over extension rules (e.g. "Makefile").
+var filenameRules map[string]string
+
+// interpreterRules maps a shebang interpreter (the last path segment of
+// the `#!` line, stripped of arguments) to a language.
+var interpreterRules map[string]string
+
+func init() {
+	if err := json.Unmarshal(extensionsData, &extensionRules); err != nil {
+		panic(fmt.Sprintf("detect: invalid embedded extensions.json: %v", err))
+	}
+	if err := json.Unmarshal(filenamesData, &filenameRules); err != nil {
+		panic(fmt.Sprintf("detect: invalid embedded filenames.json: %v", err))
+	}
+	if err := json.Unmarshal(interpretersData, &interpreterRules); err != nil {
+		panic(fmt.Sprintf("detect: invalid embedded interpreters.json: %v", err))
+	}
+}
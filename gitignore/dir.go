@@ -0,0 +1,84 @@
+package gitignore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadPatterns walks root collecting patterns from .git/info/exclude and
+// from every .gitignore file in the tree, shallowest directory first, so
+// the result can be fed to NewMatcher with correct override precedence.
+func ReadPatterns(root string) ([]*Pattern, error) {
+	var patterns []*Pattern
+
+	excludePatterns, err := readPatternFile(filepath.Join(root, ".git", "info", "exclude"), nil)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading .git/info/exclude: %w", err)
+	}
+	patterns = append(patterns, excludePatterns...)
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("relativizing %s: %w", path, err)
+		}
+		var domain []string
+		if rel != "." {
+			domain = strings.Split(filepath.ToSlash(rel), "/")
+		}
+
+		dirPatterns, err := readPatternFile(filepath.Join(path, ".gitignore"), domain)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("reading %s: %w", filepath.Join(path, ".gitignore"), err)
+		}
+		patterns = append(patterns, dirPatterns...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
+
+func readPatternFile(path string, domain []string) ([]*Pattern, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadPatternsReader(file, domain)
+}
+
+// ReadPatternsReader parses the lines of a single .gitignore-style file
+// read from r, scoping every pattern to domain. Callers that already have
+// a file's contents open (for instance from a git tree blob rather than
+// the working directory) use this directly instead of ReadPatterns.
+func ReadPatternsReader(r io.Reader, domain []string) ([]*Pattern, error) {
+	var patterns []*Pattern
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " ")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, ParsePattern(line, domain))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
@@ -0,0 +1,51 @@
+// Package gitignore implements full .gitignore semantics: "**" globs,
+// directory-only patterns, anchored patterns, negations, nested
+// .gitignore files, and .git/info/exclude, modeled on go-git's
+// plumbing/format/gitignore package.
+package gitignore
+
+// MatchResult is the outcome of testing a path against a Matcher.
+type MatchResult int
+
+const (
+	// NoMatch means no pattern touched the path; callers should treat it
+	// as not ignored.
+	NoMatch MatchResult = iota
+	// Exclude means the path is ignored.
+	Exclude
+	// Include means a negation pattern re-included a path an earlier,
+	// less specific pattern had excluded.
+	Include
+)
+
+// Matcher tests paths against an ordered set of patterns collected from
+// every .gitignore file relevant to a repository.
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// NewMatcher builds a Matcher from patterns, which must be ordered
+// shallowest-domain-first so that a later, deeper-domain pattern
+// overrides an earlier, shallower one, matching git's own precedence
+// rules. ReadPatterns returns patterns in this order.
+func NewMatcher(patterns []*Pattern) *Matcher {
+	return &Matcher{patterns: patterns}
+}
+
+// Match reports whether path (repository-root-relative, split into
+// segments) is ignored. isDir must reflect whether path names a
+// directory, since directory-only patterns only match directories.
+func (m *Matcher) Match(path []string, isDir bool) MatchResult {
+	result := NoMatch
+	for _, p := range m.patterns {
+		if !p.Match(path, isDir) {
+			continue
+		}
+		if p.negate {
+			result = Include
+		} else {
+			result = Exclude
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package gitignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatcherNegation(t *testing.T) {
+	patterns := []*Pattern{
+		ParsePattern("*.log", nil),
+		ParsePattern("!important.log", nil),
+	}
+	m := NewMatcher(patterns)
+
+	cases := []struct {
+		path []string
+		want MatchResult
+	}{
+		{[]string{"debug.log"}, Exclude},
+		{[]string{"important.log"}, Include},
+		{[]string{"notes.txt"}, NoMatch},
+	}
+	for _, c := range cases {
+		if got := m.Match(c.path, false); got != c.want {
+			t.Errorf("Match(%v) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestMatcherDirOnlyAndDoubleStar(t *testing.T) {
+	patterns := []*Pattern{
+		ParsePattern("build/", nil),
+		ParsePattern("**/vendor/**", nil),
+	}
+	m := NewMatcher(patterns)
+
+	cases := []struct {
+		path  []string
+		isDir bool
+		want  MatchResult
+	}{
+		{[]string{"build"}, true, Exclude},
+		{[]string{"build"}, false, NoMatch}, // dir-only pattern never matches a plain file
+		{[]string{"src", "vendor", "lib.go"}, false, Exclude},
+		{[]string{"vendor", "lib.go"}, false, Exclude},
+		{[]string{"src", "main.go"}, false, NoMatch},
+	}
+	for _, c := range cases {
+		if got := m.Match(c.path, c.isDir); got != c.want {
+			t.Errorf("Match(%v, isDir=%v) = %v, want %v", c.path, c.isDir, got, c.want)
+		}
+	}
+}
+
+// TestReadPatternsNestedPrecedence checks that a nested .gitignore's
+// negation only re-includes paths within its own domain, never a
+// same-named path elsewhere in the tree, matching git's own scoping.
+func TestReadPatternsNestedPrecedence(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".gitignore"), "*.log\n")
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatalf("creating sub directory: %v", err)
+	}
+	writeFile(t, filepath.Join(root, "sub", ".gitignore"), "!keep.log\n")
+
+	patterns, err := ReadPatterns(root)
+	if err != nil {
+		t.Fatalf("ReadPatterns: %v", err)
+	}
+	m := NewMatcher(patterns)
+
+	cases := []struct {
+		path []string
+		want MatchResult
+	}{
+		{[]string{"app.log"}, Exclude},
+		{[]string{"keep.log"}, Exclude}, // root-level keep.log is outside sub's negation domain
+		{[]string{"sub", "app.log"}, Exclude},
+		{[]string{"sub", "keep.log"}, Include}, // re-included by sub/.gitignore's negation
+	}
+	for _, c := range cases {
+		if got := m.Match(c.path, false); got != c.want {
+			t.Errorf("Match(%v) = %v, want %v", c.path, got, c.want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
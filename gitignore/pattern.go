@@ -0,0 +1,117 @@
+package gitignore
+
+import "path/filepath"
+
+// Pattern is a single compiled line from a .gitignore (or
+// .git/info/exclude) file, scoped to the directory (domain) that file
+// lives in.
+type Pattern struct {
+	domain   []string
+	segs     []string
+	anchored bool
+	dirOnly  bool
+	negate   bool
+}
+
+// ParsePattern compiles a single .gitignore line found in the directory
+// identified by domain (e.g. []string{"sub", "dir"} for a pattern read
+// from sub/dir/.gitignore, or nil for the repository root).
+func ParsePattern(line string, domain []string) *Pattern {
+	p := &Pattern{domain: domain}
+
+	if len(line) > 0 && line[0] == '!' {
+		p.negate = true
+		line = line[1:]
+	}
+	if len(line) > 0 && line[len(line)-1] == '/' {
+		p.dirOnly = true
+		line = line[:len(line)-1]
+	}
+	if len(line) > 0 && line[0] == '/' {
+		p.anchored = true
+		line = line[1:]
+	}
+
+	p.segs = splitSegments(line)
+	if len(p.segs) > 1 {
+		// A pattern containing a slash anywhere but the end is always
+		// anchored to its domain, per gitattributes(5).
+		p.anchored = true
+	}
+	return p
+}
+
+// Match reports whether path (the repository-root-relative path, split
+// into segments) matches p. isDir tells whether path itself names a
+// directory, which matters for directory-only patterns like "build/".
+func (p *Pattern) Match(path []string, isDir bool) bool {
+	if len(path) < len(p.domain) {
+		return false
+	}
+	for i, seg := range p.domain {
+		if path[i] != seg {
+			return false
+		}
+	}
+	rel := path[len(p.domain):]
+	if len(rel) == 0 {
+		return false
+	}
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if p.anchored {
+		return matchSegments(p.segs, rel)
+	}
+	for i := range rel {
+		if matchSegments(p.segs, rel[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitSegments(line string) []string {
+	if line == "" {
+		return nil
+	}
+	var segs []string
+	start := 0
+	for i := 0; i < len(line); i++ {
+		if line[i] == '/' {
+			segs = append(segs, line[start:i])
+			start = i + 1
+		}
+	}
+	segs = append(segs, line[start:])
+	return segs
+}
+
+// matchSegments matches pattern segments against path segments, where a
+// "**" segment consumes zero or more path segments, mirroring git's
+// treatment of "**" in fnmatch(3) mode FNM_PATHNAME.
+func matchSegments(segs, path []string) bool {
+	if len(segs) == 0 {
+		return len(path) == 0
+	}
+	if segs[0] == "**" {
+		if len(segs) == 1 {
+			return true
+		}
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(segs[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	matched, _ := filepath.Match(segs[0], path[0])
+	if !matched {
+		return false
+	}
+	return matchSegments(segs[1:], path[1:])
+}
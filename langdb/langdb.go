@@ -0,0 +1,68 @@
+// Package langdb provides GitHub Linguist's language metadata — display
+// color, extensions, and type — embedded in the binary at build time, so
+// running it from anywhere never depends on a loose JSON file sitting next
+// to it. The embedded dataset is kept up to date by the generator in
+// cmd/gen-langdb; see that package's doc comment for how to refresh it.
+package langdb
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+//go:generate go run ../cmd/gen-langdb -out languages.json
+
+//go:embed languages.json
+var languagesData []byte
+
+// Entry is one language's linguist metadata.
+type Entry struct {
+	Color      string   `json:"color"`
+	Extensions []string `json:"extensions"`
+	Aliases    []string `json:"aliases"`
+	Type       string   `json:"type"` // programming, markup, data, or prose
+}
+
+var (
+	byName      map[string]Entry
+	byExtension map[string][]string
+)
+
+func init() {
+	var entries map[string]Entry
+	if err := json.Unmarshal(languagesData, &entries); err != nil {
+		panic(fmt.Sprintf("langdb: invalid embedded languages.json: %v", err))
+	}
+	byName = entries
+
+	byExtension = make(map[string][]string, len(entries))
+	for name, entry := range entries {
+		for _, ext := range entry.Extensions {
+			byExtension[ext] = append(byExtension[ext], name)
+		}
+	}
+}
+
+// Color returns lang's GitHub display color, as used for the language bar.
+func Color(lang string) (string, bool) {
+	entry, ok := byName[lang]
+	if !ok || entry.Color == "" {
+		return "", false
+	}
+	return entry.Color, true
+}
+
+// ByExtension returns every language that claims ext (including the
+// leading dot, e.g. ".h"). The order of the returned languages is not
+// defined and can differ between runs.
+func ByExtension(ext string) []string {
+	return byExtension[strings.ToLower(ext)]
+}
+
+// Type returns lang's linguist type (programming, markup, data, or
+// prose), or "" if lang is unknown.
+func Type(lang string) string {
+	return byName[lang].Type
+}
@@ -1,117 +1,155 @@
 package main
 
 import (
-	"encoding/json"
+	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"math/rand"
 	"os"
 	"path/filepath"
-	"sort"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/kamalte/GOrgAnalyzer/analyze"
+	"github.com/kamalte/GOrgAnalyzer/langdb"
+	"github.com/kamalte/GOrgAnalyzer/report"
+	"github.com/kamalte/GOrgAnalyzer/store"
 )
 
-// LoadGitLangColors loads the language colors from a JSON file.
-func LoadGitLangColors(filePath string) (map[string]string, error) {
-	data, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+// langColors looks up each language's display color in langdb, building a
+// map sized to langCounts so report.BuildSummary never has to query langdb
+// itself.
+func langColors(langCounts map[string]int) map[string]string {
+	colors := make(map[string]string, len(langCounts))
+	for lang := range langCounts {
+		if color, ok := langdb.Color(lang); ok {
+			colors[lang] = color
+		}
 	}
+	return colors
+}
 
-	var langColors map[string]string
-	if err := json.Unmarshal(data, &langColors); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+// filterByType drops every language from langCounts whose langdb.Type
+// doesn't match only, so percentages are computed the way GitHub computes
+// its own language bar (programming-only by default). An empty only
+// leaves langCounts untouched.
+func filterByType(langCounts map[string]int, only string) map[string]int {
+	if only == "" {
+		return langCounts
 	}
 
-	return langColors, nil
+	filtered := make(map[string]int, len(langCounts))
+	for lang, count := range langCounts {
+		if langdb.Type(lang) == only {
+			filtered[lang] = count
+		}
+	}
+	return filtered
 }
 
-func generateProgressBarSVG(langByteCounts map[string]int, totalBytes int, outputPath string, githubLangColors map[string]string) error {
-	const svgHeader = `<svg xmlns="http://www.w3.org/2000/svg" width="800" height="20" style="font-family:Arial, sans-serif;">`
-	const svgFooter = `</svg>`
-
-	var svgContent strings.Builder
-	svgContent.WriteString(svgHeader)
-
-	// Create a clipPath for the rounded corners
-	svgContent.WriteString(`
-		<defs>
-			<clipPath id="roundedClip">
-				<rect x="0" y="0" width="800" height="20" rx="10" ry="10"/>
-			</clipPath>
-		</defs>
-	`)
-
-	// Sort the languages by size in descending order
-	type langData struct {
-		lang      string
-		byteCount int
-	}
-	var sortedLangs []langData
-	for lang, byteCount := range langByteCounts {
-		sortedLangs = append(sortedLangs, langData{lang, byteCount})
-	}
-	sort.Slice(sortedLangs, func(i, j int) bool {
-		return sortedLangs[i].byteCount > sortedLangs[j].byteCount
-	})
-
-	// Variables for progress bar
-	x, barHeight := 0.0, 20.0 // Increased bar height for better visibility
-	totalWidth := 800.0
-	currentX := x
-
-	// Start the progress bar group, applying the clipPath
-	svgContent.WriteString(`<g clip-path="url(#roundedClip)">`)
-
-	// Generate progress bar segments
-	for _, data := range sortedLangs {
-		percentage := float64(data.byteCount) / float64(totalBytes)
-		barWidth := totalWidth * percentage
-
-		// Use GitHub color for the language or fallback to a random color
-		color, exists := githubLangColors[data.lang]
-		if !exists {
-			color = fmt.Sprintf("#%06x", rand.Intn(0xFFFFFF))
+// findRepos returns the immediate subdirectories of basePath that are Git
+// repositories.
+func findRepos(basePath string) ([]string, error) {
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading base directory: %w", err)
+	}
+
+	var repoPaths []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		repoPath := filepath.Join(basePath, entry.Name())
+		if _, err := os.Stat(filepath.Join(repoPath, ".git")); os.IsNotExist(err) {
+			continue // Skip if not a Git repository
 		}
+		repoPaths = append(repoPaths, repoPath)
+	}
+	return repoPaths, nil
+}
 
-		// Add the rectangle for the segment
-		svgContent.WriteString(fmt.Sprintf(
-			`<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s" />`,
-			currentX, 0.0, barWidth, barHeight, color,
-		))
-		currentX += barWidth
+// runPool dispatches repoPaths across jobs worker goroutines, each
+// calling process, and folds every successful RepoResult into the
+// returned totals on the calling goroutine alone — workers never touch
+// shared state directly. onProgress, if non-nil, is called after each
+// repository completes (success or failure) with the running count.
+func runPool(repoPaths []string, jobs int, process func(string) (analyze.RepoResult, error), onProgress func(done, total int)) (map[string]int, int, map[string]int) {
+	if jobs < 1 {
+		jobs = 1
 	}
 
-	// End the group element that applies the clipPath
-	svgContent.WriteString(`</g>`)
+	jobsCh := make(chan string)
+	resultsCh := make(chan analyze.RepoResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repoPath := range jobsCh {
+				result, err := process(repoPath)
+				if err != nil {
+					log.Printf("Error processing repository %s: %v", repoPath, err)
+					// A failed repository still counts toward progress; its
+					// empty result adds nothing to the totals.
+					result = analyze.RepoResult{RepoPath: repoPath}
+				}
+				resultsCh <- result
+			}
+		}()
+	}
 
-	svgContent.WriteString(svgFooter)
+	go func() {
+		for _, repoPath := range repoPaths {
+			jobsCh <- repoPath
+		}
+		close(jobsCh)
+	}()
 
-	// Write the SVG content to the output file
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("creating SVG file: %w", err)
-	}
-	defer file.Close()
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
 
-	_, err = file.WriteString(svgContent.String())
-	if err != nil {
-		return fmt.Errorf("writing SVG content: %w", err)
+	totalLangCounts := make(map[string]int)
+	totalAuthorCommits := make(map[string]int)
+	totalBytesAnalyzed := 0
+	done := 0
+	for result := range resultsCh {
+		for lang, count := range result.LangBytes {
+			totalLangCounts[lang] += count
+		}
+		for author, commits := range result.AuthorCommits {
+			totalAuthorCommits[author] += commits
+		}
+		totalBytesAnalyzed += result.TotalBytes
+		done++
+		if onProgress != nil {
+			onProgress(done, len(repoPaths))
+		}
 	}
-
-	return nil
+	return totalLangCounts, totalBytesAnalyzed, totalAuthorCommits
 }
 
 // main orchestrates the analysis of Git repositories.
 func main() {
-	if len(os.Args) < 2 {
+	dbPath := flag.String("db", "./gorg-analyzer.db", "path to the stats cache database")
+	force := flag.Bool("force", false, "bypass the stats cache and recompute every repository")
+	incremental := flag.Bool("incremental", false, "when cached, only re-read files changed since the previously stored commit instead of doing a full rescan")
+	rev := flag.String("rev", "", "analyze this revision's committed tree instead of the working directory (e.g. main, v1.2.3)")
+	jobs := flag.Int("j", runtime.NumCPU(), "number of repositories to analyze concurrently")
+	progress := flag.Bool("progress", false, "print a live count of completed repositories")
+	formats := flag.String("format", "svg", "comma-separated report formats to write: json,md,svg,html")
+	outputDir := flag.String("output-dir", ".", "directory to write report output files into")
+	only := flag.String("only", "", "only count languages of this langdb type in percentages (e.g. programming), matching GitHub's own language bar")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
 		log.Fatal("Please provide a path to the base directory to analyze.")
 	}
 
-	basePath, err := filepath.Abs(os.Args[1])
+	basePath, err := filepath.Abs(flag.Arg(0))
 	if err != nil {
 		log.Fatalf("Error resolving absolute path: %v", err)
 	}
@@ -120,34 +158,44 @@ func main() {
 		log.Fatalf("Error: The provided path '%s' does not exist.", basePath)
 	}
 
-	entries, err := os.ReadDir(basePath)
+	repoPaths, err := findRepos(basePath)
 	if err != nil {
 		log.Fatalf("Error reading base directory: %v", err)
 	}
 
-	// Load GitHub language colors from JSON file
-	langColorsPath := "./git_lang_colors.json"
-	githubLangColors, err := LoadGitLangColors(langColorsPath)
+	statsStore, err := store.Open(*dbPath)
 	if err != nil {
-		log.Fatalf("Error loading language colors: %v", err)
+		log.Fatalf("Error opening stats cache: %v", err)
 	}
+	defer statsStore.Close()
 
-	totalLangCounts := make(map[string]int)
-	totalBytesAnalyzed := 0
+	opts := analyze.Options{Store: statsStore, Force: *force, Incremental: *incremental}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			repoPath := filepath.Join(basePath, entry.Name())
-			if _, err := os.Stat(filepath.Join(repoPath, ".git")); os.IsNotExist(err) {
-				continue // Skip if not a Git repository
-			}
+	process := func(repoPath string) (analyze.RepoResult, error) {
+		if *rev != "" {
+			return analyze.ProcessGitRepoAtRev(repoPath, *rev, opts)
+		}
+		return analyze.ProcessGitRepo(repoPath, opts)
+	}
 
-			if err := analyze.ProcessGitRepo(repoPath, totalLangCounts, &totalBytesAnalyzed); err != nil {
-				log.Printf("Error processing repository %s: %v", repoPath, err)
-			}
+	var onProgress func(done, total int)
+	if *progress {
+		onProgress = func(done, total int) {
+			fmt.Printf("\r⏳ Completed %d/%d repositories", done, total)
 		}
 	}
 
+	totalLangCounts, totalBytesAnalyzed, totalAuthorCommits := runPool(repoPaths, *jobs, process, onProgress)
+	if *progress {
+		fmt.Println()
+	}
+
+	totalLangCounts = filterByType(totalLangCounts, *only)
+	totalBytesAnalyzed = 0
+	for _, count := range totalLangCounts {
+		totalBytesAnalyzed += count
+	}
+
 	// Output final summary
 	if totalBytesAnalyzed > 0 {
 		fmt.Println("\n📊 Final Summary of Programming Languages Across All Repositories:")
@@ -156,15 +204,52 @@ func main() {
 			fmt.Printf("%s: %.2f%% (%d bytes)\n", lang, percentage, count)
 		}
 
-		// Generate cumulative progress bar SVG graphic
-		progressBarOutputPath := "./cumulative_language_progress_bar.svg"
-		err = generateProgressBarSVG(totalLangCounts, totalBytesAnalyzed, progressBarOutputPath, githubLangColors)
-		if err != nil {
-			log.Printf("Error generating progress bar SVG: %v", err)
-		} else {
-			fmt.Printf("📈 Progress bar SVG graphic generated at: %s\n", progressBarOutputPath)
+		summary := report.BuildSummary("All Repositories", totalLangCounts, totalAuthorCommits, langColors(totalLangCounts))
+		if err := writeReports(summary, *formats, *outputDir); err != nil {
+			log.Printf("Error writing reports: %v", err)
 		}
 	} else {
 		fmt.Println("\nNo programming files were analyzed across the repositories.")
 	}
 }
+
+// writeReports renders summary with every renderer named in the
+// comma-separated formats list and writes each to its own file under
+// outputDir.
+func writeReports(summary report.Summary, formats, outputDir string) error {
+	renderers := map[string]struct {
+		renderer report.Renderer
+		filename string
+	}{
+		"json": {report.JSONRenderer{}, "summary.json"},
+		"md":   {report.MarkdownRenderer{}, "summary.md"},
+		"svg":  {report.SVGRenderer{}, "cumulative_language_progress_bar.svg"},
+		"html": {report.HTMLRenderer{}, "dashboard.html"},
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("creating output directory %s: %w", outputDir, err)
+	}
+
+	for _, format := range strings.Split(formats, ",") {
+		format = strings.TrimSpace(format)
+		entry, ok := renderers[format]
+		if !ok {
+			log.Printf("Unknown report format %q, skipping", format)
+			continue
+		}
+
+		outputPath := filepath.Join(outputDir, entry.filename)
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", outputPath, err)
+		}
+		err = entry.renderer.Render(file, summary)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("rendering %s: %w", outputPath, err)
+		}
+		fmt.Printf("📈 %s report generated at: %s\n", format, outputPath)
+	}
+	return nil
+}
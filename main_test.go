@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kamalte/GOrgAnalyzer/analyze"
+)
+
+// TestRunPoolDeterministicTotals spins up many fake repositories across a
+// bounded worker pool and checks that the folded totals are the same
+// regardless of how the workers interleave. Run with -race to catch any
+// regression that goes back to writing totals from worker goroutines
+// directly instead of folding results on the calling goroutine.
+func TestRunPoolDeterministicTotals(t *testing.T) {
+	const repoCount = 200
+
+	repoPaths := make([]string, repoCount)
+	for i := range repoPaths {
+		repoPaths[i] = fmt.Sprintf("repo-%d", i)
+	}
+
+	process := func(repoPath string) (analyze.RepoResult, error) {
+		return analyze.RepoResult{
+			RepoPath:      repoPath,
+			LangBytes:     map[string]int{"Go": 100},
+			TotalBytes:    100,
+			AuthorCommits: map[string]int{"ada": 1},
+		}, nil
+	}
+
+	totalLangCounts, totalBytesAnalyzed, totalAuthorCommits := runPool(repoPaths, 16, process, nil)
+
+	if got, want := totalBytesAnalyzed, repoCount*100; got != want {
+		t.Fatalf("totalBytesAnalyzed = %d, want %d", got, want)
+	}
+	if got, want := totalLangCounts["Go"], repoCount*100; got != want {
+		t.Fatalf("totalLangCounts[Go] = %d, want %d", got, want)
+	}
+	if got, want := totalAuthorCommits["ada"], repoCount; got != want {
+		t.Fatalf("totalAuthorCommits[ada] = %d, want %d", got, want)
+	}
+}
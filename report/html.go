@@ -0,0 +1,89 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+)
+
+// HTMLRenderer renders a Summary as a self-contained HTML dashboard: a
+// stacked bar (reusing the same segments as SVGRenderer) and a
+// cumulative donut chart, with no external assets.
+type HTMLRenderer struct{}
+
+// Render writes summary to w as a standalone HTML document.
+func (HTMLRenderer) Render(w io.Writer, summary Summary) error {
+	var b strings.Builder
+
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\">")
+	fmt.Fprintf(&b, "<title>%s — Language Breakdown</title>", html.EscapeString(summary.Repo))
+	b.WriteString(`<style>
+body { font-family: Arial, sans-serif; margin: 2rem; }
+table { border-collapse: collapse; }
+td, th { padding: 0.25rem 0.75rem; text-align: left; }
+.swatch { display: inline-block; width: 0.8rem; height: 0.8rem; border-radius: 2px; margin-right: 0.4rem; }
+</style></head><body>`)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(summary.Repo))
+
+	b.WriteString(renderSVG(summary))
+	b.WriteString(renderDonut(summary))
+
+	b.WriteString("<table><tr><th>Language</th><th>Bytes</th><th>Percent</th></tr>")
+	for _, lang := range summary.Languages {
+		color := lang.Color
+		if color == "" {
+			color = "#cccccc"
+		}
+		fmt.Fprintf(&b, `<tr><td><span class="swatch" style="background:%s"></span>%s</td><td>%d</td><td>%.2f%%</td></tr>`,
+			color, html.EscapeString(lang.Name), lang.Bytes, lang.Percent)
+	}
+	b.WriteString("</table>")
+
+	if len(summary.Authors) > 0 {
+		b.WriteString("<table><tr><th>Author</th><th>Commits</th></tr>")
+		for _, author := range summary.Authors {
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%d</td></tr>", html.EscapeString(author.Name), author.Commits)
+		}
+		b.WriteString("</table>")
+	}
+
+	b.WriteString("</body></html>")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing HTML dashboard: %w", err)
+	}
+	return nil
+}
+
+// renderDonut draws a cumulative donut chart as stacked SVG <circle>
+// stroke-dasharray segments, one per language.
+func renderDonut(summary Summary) string {
+	const (
+		radius      = 80.0
+		strokeWidth = 24.0
+		size        = (radius + strokeWidth) * 2
+	)
+	circumference := 2 * math.Pi * radius
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`, size, size, size, size)
+	fmt.Fprintf(&svg, `<g transform="translate(%.0f,%.0f) rotate(-90)">`, size/2, size/2)
+
+	offset := 0.0
+	for _, lang := range summary.Languages {
+		color := lang.Color
+		if color == "" {
+			color = "#cccccc"
+		}
+		segmentLength := circumference * (lang.Percent / 100)
+		fmt.Fprintf(&svg,
+			`<circle r="%.2f" fill="none" stroke="%s" stroke-width="%.2f" stroke-dasharray="%.2f %.2f" stroke-dashoffset="-%.2f" />`,
+			radius, color, strokeWidth, segmentLength, circumference-segmentLength, offset)
+		offset += segmentLength
+	}
+
+	svg.WriteString("</g></svg>")
+	return svg.String()
+}
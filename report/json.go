@@ -0,0 +1,21 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JSONRenderer renders a Summary as indented JSON, suitable for CI
+// pipelines to parse.
+type JSONRenderer struct{}
+
+// Render writes summary to w as JSON.
+func (JSONRenderer) Render(w io.Writer, summary Summary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(summary); err != nil {
+		return fmt.Errorf("encoding JSON summary: %w", err)
+	}
+	return nil
+}
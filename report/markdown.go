@@ -0,0 +1,45 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// MarkdownRenderer renders a Summary as an inline SVG progress bar
+// followed by a Markdown table of languages (and one of authors, when
+// there are any), suitable for pasting into a README.
+type MarkdownRenderer struct{}
+
+// Render writes summary to w as Markdown.
+func (MarkdownRenderer) Render(w io.Writer, summary Summary) error {
+	if _, err := fmt.Fprintf(w, "## %s\n\n", summary.Repo); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, renderSVG(summary)+"\n\n"); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "| Language | Bytes | Percent |\n|---|---|---|\n"); err != nil {
+		return err
+	}
+	for _, lang := range summary.Languages {
+		if _, err := fmt.Fprintf(w, "| %s | %d | %.2f%% |\n", lang.Name, lang.Bytes, lang.Percent); err != nil {
+			return err
+		}
+	}
+
+	if len(summary.Authors) == 0 {
+		return nil
+	}
+
+	if _, err := io.WriteString(w, "\n| Author | Commits |\n|---|---|\n"); err != nil {
+		return err
+	}
+	for _, author := range summary.Authors {
+		if _, err := fmt.Fprintf(w, "| %s | %d |\n", author.Name, author.Commits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
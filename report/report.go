@@ -0,0 +1,81 @@
+// Package report turns an analysis summary into a specific output
+// format — JSON for CI consumption, Markdown for READMEs, an SVG
+// progress bar, or a self-contained HTML dashboard.
+package report
+
+import (
+	"io"
+	"sort"
+)
+
+// Language is one language's share of a Summary's analyzed bytes.
+type Language struct {
+	Name    string  `json:"name"`
+	Bytes   int     `json:"bytes"`
+	Percent float64 `json:"percent"`
+	Color   string  `json:"color"`
+}
+
+// Author is one author's commit count within a Summary.
+type Author struct {
+	Name    string `json:"name"`
+	Commits int    `json:"commits"`
+}
+
+// Summary is the data every Renderer consumes. Repo is a display label —
+// a single repository's path, or something like "All Repositories" for
+// an org-wide rollup.
+type Summary struct {
+	Repo      string     `json:"repo"`
+	Languages []Language `json:"languages"`
+	Authors   []Author   `json:"authors"`
+}
+
+// Renderer writes a Summary to w in a specific format.
+type Renderer interface {
+	Render(w io.Writer, summary Summary) error
+}
+
+// BuildSummary assembles a Summary from raw byte and commit counts,
+// looking up each language's display color in colors (missing entries
+// are left blank; callers render with a fallback). Languages and
+// authors are both sorted largest-first.
+func BuildSummary(repo string, langBytes map[string]int, authorCommits map[string]int, colors map[string]string) Summary {
+	totalBytes := 0
+	for _, b := range langBytes {
+		totalBytes += b
+	}
+
+	languages := make([]Language, 0, len(langBytes))
+	for name, bytes := range langBytes {
+		percent := 0.0
+		if totalBytes > 0 {
+			percent = float64(bytes) / float64(totalBytes) * 100
+		}
+		languages = append(languages, Language{
+			Name:    name,
+			Bytes:   bytes,
+			Percent: percent,
+			Color:   colors[name],
+		})
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Bytes != languages[j].Bytes {
+			return languages[i].Bytes > languages[j].Bytes
+		}
+		return languages[i].Name < languages[j].Name
+	})
+
+	authors := make([]Author, 0, len(authorCommits))
+	for name, commits := range authorCommits {
+		authors = append(authors, Author{Name: name, Commits: commits})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Commits != authors[j].Commits {
+			return authors[i].Commits > authors[j].Commits
+		}
+		return authors[i].Name < authors[j].Name
+	})
+
+	return Summary{Repo: repo, Languages: languages, Authors: authors}
+}
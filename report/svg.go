@@ -0,0 +1,67 @@
+package report
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+)
+
+// SVGRenderer renders a Summary as a single horizontal progress bar, one
+// segment per language, colored with each language's GitHub color (or a
+// deterministic per-name fallback when a Summary was built without a
+// color table entry).
+type SVGRenderer struct{}
+
+const (
+	svgWidth  = 800.0
+	svgHeight = 20.0
+)
+
+// Render writes summary to w as an SVG progress bar.
+func (SVGRenderer) Render(w io.Writer, summary Summary) error {
+	if _, err := io.WriteString(w, renderSVG(summary)); err != nil {
+		return fmt.Errorf("writing SVG: %w", err)
+	}
+	return nil
+}
+
+// renderSVG builds the SVG markup as a string so MarkdownRenderer can
+// embed it inline without round-tripping through an io.Writer.
+func renderSVG(summary Summary) string {
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" style="font-family:Arial, sans-serif;">`,
+		int(svgWidth), int(svgHeight))
+	svg.WriteString(`
+		<defs>
+			<clipPath id="roundedClip">
+				<rect x="0" y="0" width="800" height="20" rx="10" ry="10"/>
+			</clipPath>
+		</defs>
+	`)
+	svg.WriteString(`<g clip-path="url(#roundedClip)">`)
+
+	currentX := 0.0
+	for _, lang := range summary.Languages {
+		barWidth := svgWidth * (lang.Percent / 100)
+		color := lang.Color
+		if color == "" {
+			color = fallbackColor(lang.Name)
+		}
+		fmt.Fprintf(&svg, `<rect x="%.2f" y="0" width="%.2f" height="%.2f" fill="%s" />`,
+			currentX, barWidth, svgHeight, color)
+		currentX += barWidth
+	}
+
+	svg.WriteString(`</g></svg>`)
+	return svg.String()
+}
+
+// fallbackColor derives a stable color from name's hash, so a language
+// missing from the color table renders the same way on every run instead
+// of shifting between invocations.
+func fallbackColor(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("#%06x", h.Sum32()&0xFFFFFF)
+}
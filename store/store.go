@@ -0,0 +1,230 @@
+// Package store persists per-repository language and author statistics
+// in a local SQLite database, keyed by the repository's HEAD commit, so
+// that repeat runs over an unchanged repository can skip re-walking it
+// entirely.
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+-- repo_commits records that a repository has been indexed at commit_sha,
+-- independent of whether that index produced any repo_languages rows —
+-- a docs-only or fully-vendored repo upserts zero languages but must
+-- still register a cache hit on the next run.
+CREATE TABLE IF NOT EXISTS repo_commits (
+	repo_path  TEXT NOT NULL PRIMARY KEY,
+	commit_sha TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS repo_languages (
+	repo_path  TEXT NOT NULL,
+	commit_sha TEXT NOT NULL,
+	language   TEXT NOT NULL,
+	bytes      INTEGER NOT NULL,
+	PRIMARY KEY (repo_path, commit_sha, language)
+);
+
+CREATE TABLE IF NOT EXISTS repo_authors (
+	repo_path  TEXT NOT NULL,
+	commit_sha TEXT NOT NULL,
+	author     TEXT NOT NULL,
+	commits    INTEGER NOT NULL,
+	PRIMARY KEY (repo_path, commit_sha, author)
+);
+
+-- repo_files backs -incremental differential re-reads: repo_languages only
+-- keeps per-language totals, which isn't enough to know how much of a
+-- changed file's old byte count to subtract, so the indexer additionally
+-- tracks language and size per path.
+CREATE TABLE IF NOT EXISTS repo_files (
+	repo_path  TEXT NOT NULL,
+	commit_sha TEXT NOT NULL,
+	path       TEXT NOT NULL,
+	language   TEXT NOT NULL,
+	bytes      INTEGER NOT NULL,
+	PRIMARY KEY (repo_path, commit_sha, path)
+);
+`
+
+// Store is a handle to the SQLite-backed cache. It is safe for concurrent
+// use by multiple goroutines: Open pins the database to a single
+// connection, so concurrent callers (e.g. main's -j worker pool) queue
+// for it instead of racing each other into SQLITE_BUSY.
+type Store struct {
+	db *sql.DB
+}
+
+// Open creates or opens the SQLite database at path and ensures its
+// schema exists.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening store: %w", err)
+	}
+
+	// A single writer connection, serialized by database/sql itself, is
+	// simpler and more robust than relying on WAL + busy_timeout alone to
+	// paper over concurrent writers; WAL mode is kept too since it also
+	// lets readers proceed without blocking on a writer.
+	db.SetMaxOpenConns(1)
+
+	for _, pragma := range []string{`PRAGMA journal_mode=WAL`, `PRAGMA busy_timeout=5000`} {
+		if _, err := db.Exec(pragma); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("configuring store pragma %q: %w", pragma, err)
+		}
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating store schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// HeadSHA returns the commit SHA stored for repoPath, or "" if the
+// repository has never been indexed. This is sourced from repo_commits
+// rather than repo_languages, since a repository with no classified
+// files (docs-only, fully vendored) still needs to register a cache hit.
+func (s *Store) HeadSHA(repoPath string) (string, error) {
+	row := s.db.QueryRow(`SELECT commit_sha FROM repo_commits WHERE repo_path = ?`, repoPath)
+	var sha string
+	if err := row.Scan(&sha); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading stored HEAD for %s: %w", repoPath, err)
+	}
+	return sha, nil
+}
+
+// LanguageBytes returns the stored per-language byte counts for repoPath
+// at the given commit SHA.
+func (s *Store) LanguageBytes(repoPath, sha string) (map[string]int, error) {
+	rows, err := s.db.Query(`SELECT language, bytes FROM repo_languages WHERE repo_path = ? AND commit_sha = ?`, repoPath, sha)
+	if err != nil {
+		return nil, fmt.Errorf("reading stored languages for %s: %w", repoPath, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var lang string
+		var bytes int
+		if err := rows.Scan(&lang, &bytes); err != nil {
+			return nil, fmt.Errorf("scanning stored language row: %w", err)
+		}
+		counts[lang] = bytes
+	}
+	return counts, rows.Err()
+}
+
+// AuthorCommits returns the stored per-author commit counts for repoPath
+// at the given commit SHA.
+func (s *Store) AuthorCommits(repoPath, sha string) (map[string]int, error) {
+	rows, err := s.db.Query(`SELECT author, commits FROM repo_authors WHERE repo_path = ? AND commit_sha = ?`, repoPath, sha)
+	if err != nil {
+		return nil, fmt.Errorf("reading stored authors for %s: %w", repoPath, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var author string
+		var commits int
+		if err := rows.Scan(&author, &commits); err != nil {
+			return nil, fmt.Errorf("scanning stored author row: %w", err)
+		}
+		counts[author] = commits
+	}
+	return counts, rows.Err()
+}
+
+// FileLanguage is the per-path detail behind an aggregated language byte
+// count, used to compute -incremental differential updates.
+type FileLanguage struct {
+	Path     string
+	Language string
+	Bytes    int
+}
+
+// Files returns the stored per-file language and size records for
+// repoPath at the given commit SHA.
+func (s *Store) Files(repoPath, sha string) (map[string]FileLanguage, error) {
+	rows, err := s.db.Query(`SELECT path, language, bytes FROM repo_files WHERE repo_path = ? AND commit_sha = ?`, repoPath, sha)
+	if err != nil {
+		return nil, fmt.Errorf("reading stored files for %s: %w", repoPath, err)
+	}
+	defer rows.Close()
+
+	files := make(map[string]FileLanguage)
+	for rows.Next() {
+		var f FileLanguage
+		if err := rows.Scan(&f.Path, &f.Language, &f.Bytes); err != nil {
+			return nil, fmt.Errorf("scanning stored file row: %w", err)
+		}
+		files[f.Path] = f
+	}
+	return files, rows.Err()
+}
+
+// Upsert replaces any previously stored stats for repoPath with sha,
+// langBytes, authorCommits, and the per-file records they were derived
+// from, all within a single transaction.
+func (s *Store) Upsert(repoPath, sha string, langBytes map[string]int, authorCommits map[string]int, files map[string]FileLanguage) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning store transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"repo_languages", "repo_authors", "repo_files"} {
+		if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE repo_path = ?`, table), repoPath); err != nil {
+			return fmt.Errorf("clearing stored %s for %s: %w", table, repoPath, err)
+		}
+	}
+
+	if _, err := tx.Exec(
+		`INSERT INTO repo_commits (repo_path, commit_sha) VALUES (?, ?)
+		 ON CONFLICT(repo_path) DO UPDATE SET commit_sha = excluded.commit_sha`,
+		repoPath, sha,
+	); err != nil {
+		return fmt.Errorf("recording indexed commit for %s: %w", repoPath, err)
+	}
+
+	for lang, bytes := range langBytes {
+		if _, err := tx.Exec(
+			`INSERT INTO repo_languages (repo_path, commit_sha, language, bytes) VALUES (?, ?, ?, ?)`,
+			repoPath, sha, lang, bytes,
+		); err != nil {
+			return fmt.Errorf("storing language %s for %s: %w", lang, repoPath, err)
+		}
+	}
+	for author, commits := range authorCommits {
+		if _, err := tx.Exec(
+			`INSERT INTO repo_authors (repo_path, commit_sha, author, commits) VALUES (?, ?, ?, ?)`,
+			repoPath, sha, author, commits,
+		); err != nil {
+			return fmt.Errorf("storing author %s for %s: %w", author, repoPath, err)
+		}
+	}
+	for _, f := range files {
+		if _, err := tx.Exec(
+			`INSERT INTO repo_files (repo_path, commit_sha, path, language, bytes) VALUES (?, ?, ?, ?, ?)`,
+			repoPath, sha, f.Path, f.Language, f.Bytes,
+		); err != nil {
+			return fmt.Errorf("storing file %s for %s: %w", f.Path, repoPath, err)
+		}
+	}
+
+	return tx.Commit()
+}
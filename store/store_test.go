@@ -0,0 +1,54 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentUpsert runs many goroutines calling Upsert against one
+// Open'd Store at once, the way main's worker pool shares a single
+// Store across -j concurrent repositories. Run with -race to also catch
+// any regression back to concurrent writers tripping SQLITE_BUSY.
+func TestConcurrentUpsert(t *testing.T) {
+	const workers = 50
+
+	s, err := Open(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			repoPath := fmt.Sprintf("repo-%d", i)
+			errs[i] = s.Upsert(repoPath, "deadbeef",
+				map[string]int{"Go": 100},
+				map[string]int{"ada": 1},
+				map[string]FileLanguage{"main.go": {Path: "main.go", Language: "Go", Bytes: 100}})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Upsert(repo-%d) failed: %v", i, err)
+		}
+	}
+
+	for i := 0; i < workers; i++ {
+		repoPath := fmt.Sprintf("repo-%d", i)
+		sha, err := s.HeadSHA(repoPath)
+		if err != nil {
+			t.Fatalf("HeadSHA(%s): %v", repoPath, err)
+		}
+		if sha != "deadbeef" {
+			t.Errorf("HeadSHA(%s) = %q, want %q", repoPath, sha, "deadbeef")
+		}
+	}
+}